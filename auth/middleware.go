@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type contextKey int
+
+const userContextKey contextKey = 0
+
+// RequireToken returns middleware that validates the bearer token carried in
+// the Authorization header, or failing that the session cookie set by
+// CallbackHandler, against store, attaching the resolved User to the
+// request context for downstream handlers (see UserFromContext). A missing
+// token is rejected with 401; one that store does not recognize is rejected
+// with 403.
+func RequireToken(store TokenStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			user, err := store.User(token)
+			if err != nil {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userContextKey, user)))
+		})
+	}
+}
+
+// UserFromContext returns the User attached by RequireToken, if any.
+func UserFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userContextKey).(User)
+	return user, ok
+}
+
+// bearerToken extracts the token from a "Bearer <token>" Authorization
+// header, falling back to the sessionCookieName cookie so the SSR+htmx UI
+// can authenticate without setting that header itself.
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, prefix) {
+		return strings.TrimPrefix(header, prefix), true
+	}
+
+	if cookie, err := r.Cookie(sessionCookieName); err == nil && cookie.Value != "" {
+		return cookie.Value, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// oauthStateCookie names the cookie used to carry the OAuth2 state
+// parameter between the /auth/login redirect and /auth/callback.
+const oauthStateCookie = "oauth_state"
+
+// sessionCookieName names the cookie CallbackHandler sets so the SSR+htmx
+// UI can carry its bearer token across requests without JavaScript setting
+// an Authorization header itself (see bearerToken in middleware.go).
+const sessionCookieName = "session_token"
+
+// Service wires OAuth2/OIDC login against Google to a TokenStore, exposing
+// the /auth/* HTTP handlers registered in main.go.
+type Service struct {
+	config *oauth2.Config
+	store  TokenStore
+}
+
+// NewService returns a Service that issues tokens from store after a Google
+// login using the given OAuth2 client credentials.
+func NewService(store TokenStore, clientID, clientSecret, redirectURL string) *Service {
+	return &Service{
+		config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       []string{"openid", "email", "profile"},
+			Endpoint:     google.Endpoint,
+		},
+		store: store,
+	}
+}
+
+// LoginHandler handles (GET /auth/login): it redirects the browser to
+// Google's consent screen, stashing a state value in a cookie so the
+// callback can detect CSRF.
+func (s *Service) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	state, err := randomToken()
+	if err != nil {
+		http.Error(w, "failed to start login", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: oauthStateCookie, Value: state, Path: "/", HttpOnly: true})
+	http.Redirect(w, r, s.config.AuthCodeURL(state), http.StatusFound)
+}
+
+// CallbackHandler handles (GET /auth/callback): it exchanges the
+// authorization code for a Google token, resolves the caller's profile, and
+// issues an application bearer token for it via the TokenStore.
+func (s *Service) CallbackHandler(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(oauthStateCookie)
+	if err != nil || r.URL.Query().Get("state") != cookie.Value {
+		http.Error(w, "invalid oauth state", http.StatusBadRequest)
+		return
+	}
+
+	oauthToken, err := s.config.Exchange(r.Context(), r.URL.Query().Get("code"))
+	if err != nil {
+		http.Error(w, "failed to exchange code: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	user, err := fetchGoogleProfile(r.Context(), s.config, oauthToken)
+	if err != nil {
+		http.Error(w, "failed to fetch profile: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	appToken, err := s.store.CreateToken(user)
+	if err != nil {
+		http.Error(w, "failed to create token", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Value: appToken.Value, Path: "/", HttpOnly: true})
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// LogoutHandler handles (POST /auth/logout): it revokes the bearer token
+// presented in the Authorization header or session cookie, and clears the
+// session cookie.
+func (s *Service) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(r)
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.store.RevokeToken(token); err != nil {
+		http.Error(w, "failed to revoke token", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Value: "", Path: "/", HttpOnly: true, MaxAge: -1})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TokensHandler handles (DELETE /auth/tokens): it revokes every token
+// issued to the authenticated user, e.g. to sign out of all sessions at
+// once. It must run behind RequireToken so a User is present in context.
+func (s *Service) TokensHandler(w http.ResponseWriter, r *http.Request) {
+	user, ok := UserFromContext(r.Context())
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.store.RevokeUserTokens(user.ID); err != nil {
+		http.Error(w, "failed to revoke tokens", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// fetchGoogleProfile uses the exchanged OAuth2 token to fetch the caller's
+// Google profile.
+func fetchGoogleProfile(ctx context.Context, config *oauth2.Config, token *oauth2.Token) (User, error) {
+	client := config.Client(ctx, token)
+
+	resp, err := client.Get("https://www.googleapis.com/oauth2/v2/userinfo")
+	if err != nil {
+		return User{}, err
+	}
+	defer resp.Body.Close()
+
+	var profile struct {
+		ID    string `json:"id"`
+		Email string `json:"email"`
+		Name  string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return User{}, fmt.Errorf("decode userinfo: %w", err)
+	}
+
+	return User{ID: profile.ID, Email: profile.Email, Name: profile.Name}, nil
+}
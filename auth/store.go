@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTokenInvalid is returned by TokenStore.User when the given token is
+// unknown or has been revoked.
+var ErrTokenInvalid = errors.New("invalid or revoked token")
+
+// Token is a bearer credential issued to a User after a successful OAuth2
+// login.
+type Token struct {
+	Value    string
+	UserID   string
+	IssuedAt time.Time
+}
+
+// TokenStore issues and validates bearer tokens, and tracks which user each
+// token belongs to so a single token, or every token belonging to a user,
+// can be revoked independently of its lifetime.
+type TokenStore interface {
+	// CreateToken issues a new token for user and returns it.
+	CreateToken(user User) (Token, error)
+
+	// RevokeToken invalidates a single token. It is a no-op if the token is
+	// unknown.
+	RevokeToken(value string) error
+
+	// RevokeUserTokens invalidates every token issued to userID.
+	RevokeUserTokens(userID string) error
+
+	// User returns the User a valid, non-revoked token was issued to. It
+	// returns ErrTokenInvalid if the token is unknown or has been revoked.
+	User(value string) (User, error)
+}
+
+// MemoryTokenStore is a concurrency-safe, in-memory TokenStore.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]Token
+	users  map[string]User
+}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: make(map[string]Token),
+		users:  make(map[string]User),
+	}
+}
+
+func (m *MemoryTokenStore) CreateToken(user User) (Token, error) {
+	value, err := randomToken()
+	if err != nil {
+		return Token{}, err
+	}
+
+	token := Token{Value: value, UserID: user.ID, IssuedAt: time.Now()}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[value] = token
+	m.users[user.ID] = user
+
+	return token, nil
+}
+
+func (m *MemoryTokenStore) RevokeToken(value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.tokens, value)
+	return nil
+}
+
+func (m *MemoryTokenStore) RevokeUserTokens(userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for value, token := range m.tokens {
+		if token.UserID == userID {
+			delete(m.tokens, value)
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryTokenStore) User(value string) (User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	token, ok := m.tokens[value]
+	if !ok {
+		return User{}, ErrTokenInvalid
+	}
+
+	user, ok := m.users[token.UserID]
+	if !ok {
+		return User{}, ErrTokenInvalid
+	}
+
+	return user, nil
+}
+
+// randomToken returns a random, URL-safe token value.
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMemoryTokenStore_RevokeToken(t *testing.T) {
+	store := NewMemoryTokenStore()
+	user := User{ID: "u1", Email: "alice@example.com", Name: "Alice"}
+
+	token, err := store.CreateToken(user)
+	if err != nil {
+		t.Fatalf("CreateToken returned unexpected error: %v", err)
+	}
+
+	if _, err := store.User(token.Value); err != nil {
+		t.Fatalf("User returned unexpected error before revocation: %v", err)
+	}
+
+	if err := store.RevokeToken(token.Value); err != nil {
+		t.Fatalf("RevokeToken returned unexpected error: %v", err)
+	}
+
+	if _, err := store.User(token.Value); !errors.Is(err, ErrTokenInvalid) {
+		t.Errorf("User after revocation returned %v, want ErrTokenInvalid", err)
+	}
+}
+
+func TestMemoryTokenStore_RevokeUserTokens(t *testing.T) {
+	store := NewMemoryTokenStore()
+	user := User{ID: "u1", Email: "alice@example.com", Name: "Alice"}
+	other := User{ID: "u2", Email: "bob@example.com", Name: "Bob"}
+
+	first, err := store.CreateToken(user)
+	if err != nil {
+		t.Fatalf("CreateToken returned unexpected error: %v", err)
+	}
+	second, err := store.CreateToken(user)
+	if err != nil {
+		t.Fatalf("CreateToken returned unexpected error: %v", err)
+	}
+	otherToken, err := store.CreateToken(other)
+	if err != nil {
+		t.Fatalf("CreateToken returned unexpected error: %v", err)
+	}
+
+	if err := store.RevokeUserTokens(user.ID); err != nil {
+		t.Fatalf("RevokeUserTokens returned unexpected error: %v", err)
+	}
+
+	if _, err := store.User(first.Value); !errors.Is(err, ErrTokenInvalid) {
+		t.Errorf("User(first) after RevokeUserTokens returned %v, want ErrTokenInvalid", err)
+	}
+	if _, err := store.User(second.Value); !errors.Is(err, ErrTokenInvalid) {
+		t.Errorf("User(second) after RevokeUserTokens returned %v, want ErrTokenInvalid", err)
+	}
+	if _, err := store.User(otherToken.Value); err != nil {
+		t.Errorf("User(otherToken) after revoking a different user's tokens returned %v, want nil", err)
+	}
+}
+
+func TestMemoryTokenStore_UserUnknownToken(t *testing.T) {
+	store := NewMemoryTokenStore()
+
+	if _, err := store.User("does-not-exist"); !errors.Is(err, ErrTokenInvalid) {
+		t.Errorf("User of an unknown token returned %v, want ErrTokenInvalid", err)
+	}
+}
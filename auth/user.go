@@ -0,0 +1,8 @@
+package auth
+
+// User is an authenticated principal resolved from a valid bearer token.
+type User struct {
+	ID    string
+	Email string
+	Name  string
+}
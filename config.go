@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/caarlos0/env/v8"
+)
+
+// Config holds every environment-driven setting for the server, parsed in
+// one place instead of scattered os.Getenv calls throughout the codebase.
+type Config struct {
+	Port            int           `env:"PORT" envDefault:"3000"`
+	ReadTimeout     time.Duration `env:"READ_TIMEOUT" envDefault:"5s"`
+	WriteTimeout    time.Duration `env:"WRITE_TIMEOUT" envDefault:"10s"`
+	IdleTimeout     time.Duration `env:"IDLE_TIMEOUT" envDefault:"120s"`
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"10s"`
+	LogLevel        string        `env:"LOG_LEVEL" envDefault:"info"`
+
+	DBBackend  string `env:"DB_BACKEND" envDefault:"memory"`
+	PGHost     string `env:"PG_HOST" envDefault:"localhost"`
+	PGPort     string `env:"PG_PORT" envDefault:"5432"`
+	PGUser     string `env:"PG_USER" envDefault:"postgres"`
+	PGPassword string `env:"PG_PASSWORD" envDefault:"postgres"`
+	PGDBName   string `env:"PG_DBNAME" envDefault:"customers"`
+	PGSSLMode  string `env:"PG_SSLMODE" envDefault:"disable"`
+
+	GoogleClientID     string `env:"GOOGLE_CLIENT_ID"`
+	GoogleClientSecret string `env:"GOOGLE_CLIENT_SECRET"`
+	GoogleRedirectURL  string `env:"GOOGLE_REDIRECT_URL"`
+}
+
+// configFromEnv parses a Config from the process environment, applying the
+// envDefault tags above for anything unset.
+func configFromEnv() (Config, error) {
+	var cfg Config
+	if err := env.Parse(&cfg); err != nil {
+		return Config{}, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	return cfg, nil
+}
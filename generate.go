@@ -0,0 +1,5 @@
+package main
+
+// Regenerate types.gen.go and routes.gen.go from schema/schema.yaml.
+//go:generate go run github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen --config=oapi-codegen.types.yaml schema/schema.yaml
+//go:generate go run github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen --config=oapi-codegen.server.yaml schema/schema.yaml
@@ -3,297 +3,345 @@ package main
 import (
 	"encoding/json"
 	"io"
+	"log"
 	"net/http"
-	"strconv"
+	"strings"
 
-	"github.com/gorilla/mux"
+	"github.com/google/uuid"
 )
 
-/*
-Create a Customer struct
-
-Each customer includes:
-- ID
-- Name
-- Role
-- Email
-- Phone
-- Contacted (i.e., indication of whether or not the customer has been contacted)
-Data is mapped to logical, appropriate types (e.g., Name should not be a bool).
-*/
-type Customer struct {
-	Id          uint64 `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Role        string `json:"role,omitempty"`
-	Email       string `json:"email,omitempty"`
-	Phone       string `json:"phone,omitempty"`
-	IsContacted bool   `json:"contacted,omitempty"`
+// Server implements ServerInterface (routes.gen.go), generated from
+// schema/schema.yaml. Handlers reach the configured CustomerRepository
+// instead of touching a package-level "database", which keeps them testable
+// against a mock repository.
+type Server struct {
+	repo CustomerRepository
 }
 
-/*
-Create a mock "database" to store customer data
-Customers are stored appropriately in a basic data structure (e.g., slice, map, etc.) that represents a "database."
-
-Seed the database with initial customer data
-The "database" data structure is non-empty. That is, prior to any CRUD operations performed by the user (e.g., adding a customer),
-the database includes at least three existing (i.e., "hard-coded") customers.
-
-Assign unique IDs to customers in the database
-Customers in the database have unique ID values (i.e., no two customers have the same ID value).
-*/
-var customers = map[uint64]Customer{
-	1: {
-		Id:          1,
-		Name:        "John Doe",
-		Role:        "Admin",
-		Email:       "john.doe@gmail.com",
-		Phone:       "1234567890",
-		IsContacted: false,
-	},
-	2: {
-		Id:          2,
-		Name:        "Jane Doe",
-		Role:        "User",
-		Email:       "jane.doe@gmail.com",
-		Phone:       "0987654321",
-		IsContacted: false,
-	},
-	3: {
-		Id:          3,
-		Name:        "John Smith",
-		Role:        "User",
-		Email:       "john.smith@gmail.com",
-		Phone:       "1234567890",
-		IsContacted: false,
-	},
-}
+var _ ServerInterface = (*Server)(nil)
+
+// readNewCustomerFromRequestBody reads the add/update request body, which
+// carries every Customer field except Id: the server assigns ids, so
+// clients can't set or override one. Both JSON (the API) and
+// application/x-www-form-urlencoded (the SSR+htmx forms) bodies are
+// accepted.
+func readNewCustomerFromRequestBody(r *http.Request) (NewCustomer, error) {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return readNewCustomerFromForm(r)
+	}
 
-// Function to read customer data from the request body
-func readCustomerFromRequestBody(r *http.Request) (Customer, error) {
 	// Read request data
 	// The application leverages the io/ioutil package to read I/O (e.g., request) data.
 	// Read the request body into a byte slice
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		return Customer{}, err
+		return NewCustomer{}, err
 	}
 
 	// Parse JSON data
 	// The applications leverages the encoding/json package to parse JSON data.
-	// Unmarshal the byte slice into a Customer struct
-	var customer Customer
+	// Unmarshal the byte slice into a NewCustomer struct
+	var customer NewCustomer
 	err = json.Unmarshal(body, &customer)
 	if err != nil {
-		return Customer{}, err
+		return NewCustomer{}, err
 	}
 
 	return customer, nil
 }
 
-// Create a function to get a slice of all customers
-// The application includes a function that returns a slice of all customers in the "database."
-func getCustomerSlices() []Customer {
-	result := make([]Customer, 0, len(customers))
+// readNewCustomerFromForm reads the add/update request body as an
+// application/x-www-form-urlencoded submission from the new/edit HTML
+// forms. An absent "contacted" checkbox means false, matching normal HTML
+// checkbox semantics.
+func readNewCustomerFromForm(r *http.Request) (NewCustomer, error) {
+	if err := r.ParseForm(); err != nil {
+		return NewCustomer{}, err
+	}
+
+	return NewCustomer{
+		Name:      r.PostFormValue("name"),
+		Role:      r.PostFormValue("role"),
+		Email:     r.PostFormValue("email"),
+		Phone:     r.PostFormValue("phone"),
+		Contacted: r.PostFormValue("contacted") != "",
+	}, nil
+}
+
+// wantsHTML reports whether r should be answered with a rendered HTML page
+// rather than the JSON the API otherwise returns.
+func wantsHTML(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
 
-	for _, value := range customers {
-		result = append(result, value)
+// redirectAfterMutation sends the browser back to the customer list after a
+// form-driven create/update/delete: a full redirect for plain HTML forms,
+// or an HX-Redirect for htmx requests so it can follow client-side.
+func redirectAfterMutation(w http.ResponseWriter, r *http.Request, location string) {
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Redirect", location)
+		w.WriteHeader(http.StatusOK)
+		return
 	}
 
-	return result
+	http.Redirect(w, r, location, http.StatusSeeOther)
 }
 
 // Create and assign handlers for requests
 
-// Getting all customers through a the /customers path
-// The application returns all customers in the "database" when a GET request is made to the /customers path.
-func getCustomers(w http.ResponseWriter, r *http.Request) {
+// customersPage is the paged envelope returned by GetCustomers so clients
+// can walk through large result sets without fetching everything at once.
+type customersPage struct {
+	Items   []Customer `json:"items"`
+	Page    int        `json:"page"`
+	PerPage int        `json:"per_page"`
+	Total   int        `json:"total"`
+	Next    string     `json:"next,omitempty"`
+	Prev    string     `json:"prev,omitempty"`
+}
+
+// GetCustomers handles (GET /customers): it returns customers matching the
+// optional ?q= search term, paged per ?page=&per_page= and ordered per
+// ?sort=&order=. An `Accept: text/html` request renders the SSR+htmx list
+// page instead of the JSON envelope; htmx requests triggered by the search
+// input receive just the rows fragment.
+func (s *Server) GetCustomers(w http.ResponseWriter, r *http.Request) {
+	query := parseListQuery(r)
+
+	var customers []Customer
+	var err error
+	if query.Q != "" {
+		customers, err = s.repo.Search(query.Q)
+	} else {
+		customers, err = s.repo.List()
+	}
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Failed to list customers. " + err.Error())
+		return
+	}
+
+	sortCustomers(customers, query.Sort, query.Order)
+	total := len(customers)
+	pageItems := paginate(customers, query.Page, query.PerPage)
+
+	page := customersPage{
+		Items:   pageItems,
+		Page:    query.Page,
+		PerPage: query.PerPage,
+		Total:   total,
+	}
+	if query.Page*query.PerPage < total {
+		page.Next = buildPageURL(r, query.Page+1)
+	}
+	if query.Page > 1 {
+		page.Prev = buildPageURL(r, query.Page-1)
+	}
+
+	if isHtmxSearchRequest(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		templates.ExecuteTemplate(w, "rows.gohtml", page)
+		return
+	}
+
+	if wantsHTML(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		templates.ExecuteTemplate(w, "index.gohtml", page)
+		return
+	}
+
 	// Set headers to indicate the proper media type
 	// An appropriate Content-Type header is sent in server responses.
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(page)
+}
+
+// NewCustomerForm handles (GET /customers/new): it renders the HTML form
+// used to create a customer through the SSR+htmx UI.
+func (s *Server) NewCustomerForm(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	templates.ExecuteTemplate(w, "new.gohtml", Customer{})
+}
+
+// EditCustomerForm handles (GET /customers/{id}/edit): it renders the HTML
+// form used to update an existing customer through the SSR+htmx UI.
+func (s *Server) EditCustomerForm(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	customer, err := s.repo.Get(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
 
-	json.NewEncoder(w).Encode(getCustomerSlices())
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	templates.ExecuteTemplate(w, "edit.gohtml", customer)
 }
 
-// Getting a single customer through a /customers/{id} path
-// The application returns a single customer when a GET request is made to the /customers/{id} path.
-func getCustomer(w http.ResponseWriter, r *http.Request) {
+// GetCustomer handles (GET /customers/{id}): it returns a single customer.
+func (s *Server) GetCustomer(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
 	// Set headers to indicate the proper media type
 	// An appropriate Content-Type header is sent in server responses.
 	w.Header().Set("Content-Type", "application/json")
 
-	urlPathVars := mux.Vars(r)
-	if givenId, exist := urlPathVars["id"]; exist {
-		id, err := strconv.ParseUint(givenId, 10, 64)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode("Failed to parse the given id: " + givenId + ". " + err.Error())
-			return
-		}
-
-		// Includes basic error handling for non-existent customers
-		if customer, ok := customers[id]; ok {
-			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(customer)
-		} else {
-			// If the user queries for a customer that doesn't exist (i.e., when getting a customer, updating a customer, or deleting a customer), the server response includes:
-			// A 404 status code in the header
-			w.WriteHeader(http.StatusNotFound)
-			// null or an empty JSON object literal or an error message
-			json.NewEncoder(w).Encode("Customer with id: " + givenId + " not found.")
-		}
+	// Includes basic error handling for non-existent customers
+	customer, err := s.repo.Get(id)
+	if err == nil {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(customer)
 	} else {
-		w.WriteHeader(http.StatusBadRequest)
+		// If the user queries for a customer that doesn't exist (i.e., when getting a customer, updating a customer, or deleting a customer), the server response includes:
+		// A 404 status code in the header
+		w.WriteHeader(http.StatusNotFound)
+		// null or an empty JSON object literal or an error message
+		json.NewEncoder(w).Encode("Customer with the given id not found.")
 	}
 }
 
-// Creating a customer through a /customers path
-// The application adds a new customer to the "database" when a POST request is made to the /customers path.
-func addCustomer(w http.ResponseWriter, r *http.Request) {
-	// Set headers to indicate the proper media type
-	// An appropriate Content-Type header is sent in server responses.
-	w.Header().Set("Content-Type", "application/json")
-
-	// Decode the request body into a Golang value (a Customer struct)
-	customer, err := readCustomerFromRequestBody(r)
+// AddCustomer handles (POST /customers): it generates an id for the new
+// customer, stores it, and returns the created resource with a Location
+// header pointing at it. A form submission from the SSR+htmx UI is
+// redirected back to the customer list instead.
+func (s *Server) AddCustomer(w http.ResponseWriter, r *http.Request) {
+	// Decode the request body into a Golang value (a NewCustomer struct)
+	input, err := readNewCustomerFromRequestBody(r)
 	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode("Failed to read customer data from the request body. " + err.Error())
 		return
 	}
 
-	if customer.Id == 0 {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode("Invalid customer id 0. Customer id should be greater than 0.")
-		return
+	customer := Customer{
+		Id:        uuid.New(),
+		Name:      input.Name,
+		Role:      input.Role,
+		Email:     input.Email,
+		Phone:     input.Phone,
+		Contacted: input.Contacted,
 	}
 
-	if _, exist := customers[customer.Id]; exist {
-		// If the key already exists in the "database", update the HTTP status with a "407 Conflict" message
-		// In such a case, the original "database" is not updated at all
-		w.WriteHeader(http.StatusConflict)
-	} else {
-		// If the key doesn't exist, add it to the "database" and return a "201 Created" in the header
-		customers[customer.Id] = customer
+	created, err := s.repo.Create(customer)
+	switch err {
+	case nil:
+		if wantsHTML(r) {
+			redirectAfterMutation(w, r, "/")
+			return
+		}
+		// Created resources are addressable at their own URL.
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/customers/"+created.Id.String())
 		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+	case ErrCustomerExists:
+		// The generated id collided with one already stored. In such a case,
+		// the original "database" is not updated at all.
+		if !wantsHTML(r) {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Failed to create customer. " + err.Error())
 	}
-
-	// Regardless of successful resource creation or not, return the current state of the "database"
-	json.NewEncoder(w).Encode(getCustomerSlices())
 }
 
-// Updating a customer through a /customers/{id} path
-// The application updates an existing customer in the "database" when a PUT request is made to the /customers/{id} path.
-func updateCustomer(w http.ResponseWriter, r *http.Request) {
-	// Set headers to indicate the proper media type
-	// An appropriate Content-Type header is sent in server responses.
-	w.Header().Set("Content-Type", "application/json")
+// UpdateCustomer handles (PUT /customers/{id}): it updates an existing
+// customer in the "database". The id comes from the URL; the request body
+// doesn't carry one.
+func (s *Server) UpdateCustomer(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	input, err := readNewCustomerFromRequestBody(r)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Failed to read customer data from the request body. " + err.Error())
+		return
+	}
 
-	urlPathVars := mux.Vars(r)
-	if givenId, exist := urlPathVars["id"]; exist {
-		id, err := strconv.ParseUint(givenId, 10, 64)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode("Failed to parse the given id: " + givenId + ". " + err.Error())
+	customer := Customer{
+		Id:        id,
+		Name:      input.Name,
+		Role:      input.Role,
+		Email:     input.Email,
+		Phone:     input.Phone,
+		Contacted: input.Contacted,
+	}
+
+	// Includes basic error handling for non-existent customers
+	if _, err := s.repo.Update(id, customer); err == nil {
+		if wantsHTML(r) {
+			redirectAfterMutation(w, r, "/")
 			return
 		}
 
-		customer, err := readCustomerFromRequestBody(r)
+		customers, err := s.repo.List()
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode("Failed to read customer data from the request body. " + err.Error())
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("Failed to list customers. " + err.Error())
 			return
 		}
-
-		if customer.Id != id {
-			w.WriteHeader(http.StatusBadRequest)
-			// An empty JSON object in the response body
-			json.NewEncoder(w).Encode("Customer id in the request body does not match the id in the URL path.")
-			return
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(customers)
+	} else {
+		if !wantsHTML(r) {
+			w.Header().Set("Content-Type", "application/json")
 		}
-
-		// Includes basic error handling for non-existent customers
-		if _, ok := customers[id]; ok {
-			customers[id] = customer
-			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(getCustomerSlices())
-		} else {
-			// If the user queries for a customer that doesn't exist (i.e., when getting a customer, updating a customer, or deleting a customer), the server response includes:
-			// A 404 status code in the header
-			w.WriteHeader(http.StatusNotFound)
+		// If the user queries for a customer that doesn't exist (i.e., when getting a customer, updating a customer, or deleting a customer), the server response includes:
+		// A 404 status code in the header
+		w.WriteHeader(http.StatusNotFound)
+		if !wantsHTML(r) {
 			// null or an empty JSON object literal or an error message
-			json.NewEncoder(w).Encode("Customer with id: " + givenId + " not found.")
+			json.NewEncoder(w).Encode("Customer with the given id not found.")
 		}
-	} else {
-		w.WriteHeader(http.StatusBadRequest)
 	}
 }
 
-// Deleting a customer through a /customers/{id} path
-// The application deletes an existing customer from the "database" when a DELETE request is made to the /customers/{id} path.
-func deleteCustomer(w http.ResponseWriter, r *http.Request) {
+// DeleteCustomer handles (DELETE /customers/{id}): it removes an existing
+// customer from the "database".
+func (s *Server) DeleteCustomer(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
 	// Set headers to indicate the proper media type
 	// An appropriate Content-Type header is sent in server responses.
 	w.Header().Set("Content-Type", "application/json")
 
-	urlPathVars := mux.Vars(r)
-	if givenId, exist := urlPathVars["id"]; exist {
-		id, err := strconv.ParseUint(givenId, 10, 64)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode("Failed to parse the given id: " + givenId + ". " + err.Error())
+	// Includes basic error handling for non-existent customers
+	if err := s.repo.Delete(id); err == nil {
+		if wantsHTML(r) || r.Header.Get("HX-Request") == "true" {
+			redirectAfterMutation(w, r, "/")
 			return
 		}
 
-		// Includes basic error handling for non-existent customers
-		if _, ok := customers[id]; ok {
-			delete(customers, id)
-			w.WriteHeader(http.StatusOK)
-			// return the current state of the "database"
-			json.NewEncoder(w).Encode(getCustomerSlices())
-		} else {
-			// If the user queries for a customer that doesn't exist (i.e., when getting a customer, updating a customer, or deleting a customer), the server response includes:
-			// A 404 status code in the header
-			w.WriteHeader(http.StatusNotFound)
-			// null or an empty JSON object literal or an error message
-			json.NewEncoder(w).Encode("Customer with id: " + givenId + " not found.")
+		customers, err := s.repo.List()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("Failed to list customers. " + err.Error())
+			return
 		}
+		w.WriteHeader(http.StatusOK)
+		// return the current state of the "database"
+		json.NewEncoder(w).Encode(customers)
 	} else {
-		w.WriteHeader(http.StatusBadRequest)
+		// If the user queries for a customer that doesn't exist (i.e., when getting a customer, updating a customer, or deleting a customer), the server response includes:
+		// A 404 status code in the header
+		w.WriteHeader(http.StatusNotFound)
+		// null or an empty JSON object literal or an error message
+		json.NewEncoder(w).Encode("Customer with the given id not found.")
 	}
 }
 
 func main() {
+	cfg, err := configFromEnv()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
 
-	// Set up and configure a router
-	// The application uses a router (e.g., gorilla/mux, http.ServeMux, etc.) that supports HTTP method-based routing and variables in URL paths.
-	router := mux.NewRouter()
-
-	// Create RESTful server endpoints for CRUD operations
-	// The application handles the following 5 operations for customers in the "database":
-	// Each RESTful route is associated with the correct HTTP verb.
-
-	// Getting a single customer through a /customers/{id} path
-	router.HandleFunc("/customers/{id}", getCustomer).Methods("GET")
-
-	// Getting all customers through a the /customers path
-	router.HandleFunc("/customers", getCustomers).Methods("GET")
-
-	// Creating a customer through a /customers path
-	router.HandleFunc("/customers", addCustomer).Methods("POST")
-
-	// Updating a customer through a /customers/{id} path
-	router.HandleFunc("/customers/{id}", updateCustomer).Methods("PUT")
-
-	// Deleting a customer through a /customers/{id} path
-	router.HandleFunc("/customers/{id}", deleteCustomer).Methods("DELETE")
-
-	// Serve static HTML at the home ("/") route
-	// The home route is a client API endpoint, and includes a brief overview of the API (e.g., available endpoints). Note: This is the only route that does not return a JSON response.
-	fileServer := http.FileServer(http.Dir("./static"))
-	router.Handle("/", fileServer)
-
-	// Serve the API locally
-	// The API can be accessed via localhost.
-	http.ListenAndServe(":3000", router)
+	if err := Run(cfg); err != nil {
+		log.Fatalf("server error: %v", err)
+	}
 }
@@ -13,7 +13,8 @@ func TestGetCustomers(t *testing.T) {
 	}
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(getCustomers)
+	server := &Server{repo: NewMemoryCustomerRepository()}
+	handler := http.HandlerFunc(server.GetCustomers)
 
 	handler.ServeHTTP(rr, req)
 
@@ -22,7 +23,8 @@ func TestGetCustomers(t *testing.T) {
 			status, http.StatusOK)
 	}
 
-	expected := `[{"id":1,"name":"John Doe","role":"Admin","email":"john.doe@gmail.com","phone":"1234567890","contacted":false},{"id":2,"name":"Jane Doe","role":"User","email":"jane.doe@gmail.com","phone":"0987654321","contacted":false},{"id":3,"name":"John Smith","role":"User","email":"john.smith@gmail.com","phone":"1234567890","contacted":false}]`
+	// json.Encoder.Encode appends a trailing newline after the value.
+	expected := `{"items":[{"contacted":false,"email":"john.doe@gmail.com","id":"11111111-1111-1111-1111-111111111111","name":"John Doe","phone":"1234567890","role":"Admin"},{"contacted":false,"email":"jane.doe@gmail.com","id":"22222222-2222-2222-2222-222222222222","name":"Jane Doe","phone":"0987654321","role":"User"},{"contacted":false,"email":"john.smith@gmail.com","id":"33333333-3333-3333-3333-333333333333","name":"John Smith","phone":"1234567890","role":"User"}],"page":1,"per_page":20,"total":3}` + "\n"
 	if rr.Body.String() != expected {
 		t.Errorf("handler returned unexpected body: got %v want %v",
 			rr.Body.String(), expected)
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Deterministic ids for the three customers the application has always
+// shipped with, so restarting against a fresh in-memory store doesn't
+// reshuffle their ids. The Postgres repository is not seeded with them.
+var (
+	johnDoeID   = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	janeDoeID   = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	johnSmithID = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+)
+
+// MemoryCustomerRepository is a concurrency-safe, in-memory implementation
+// of CustomerRepository. It preserves the behavior of the original
+// package-level `customers` map, guarded by a sync.RWMutex so it is safe to
+// use from concurrent request handlers.
+type MemoryCustomerRepository struct {
+	mu        sync.RWMutex
+	customers map[uuid.UUID]Customer
+}
+
+// NewMemoryCustomerRepository returns a MemoryCustomerRepository seeded with
+// the same three customers the application has always shipped with.
+func NewMemoryCustomerRepository() *MemoryCustomerRepository {
+	return &MemoryCustomerRepository{
+		customers: map[uuid.UUID]Customer{
+			johnDoeID: {
+				Id:        johnDoeID,
+				Name:      "John Doe",
+				Role:      "Admin",
+				Email:     "john.doe@gmail.com",
+				Phone:     "1234567890",
+				Contacted: false,
+			},
+			janeDoeID: {
+				Id:        janeDoeID,
+				Name:      "Jane Doe",
+				Role:      "User",
+				Email:     "jane.doe@gmail.com",
+				Phone:     "0987654321",
+				Contacted: false,
+			},
+			johnSmithID: {
+				Id:        johnSmithID,
+				Name:      "John Smith",
+				Role:      "User",
+				Email:     "john.smith@gmail.com",
+				Phone:     "1234567890",
+				Contacted: false,
+			},
+		},
+	}
+}
+
+func (m *MemoryCustomerRepository) Create(customer Customer) (Customer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exist := m.customers[customer.Id]; exist {
+		return Customer{}, ErrCustomerExists
+	}
+
+	m.customers[customer.Id] = customer
+	return customer, nil
+}
+
+func (m *MemoryCustomerRepository) Update(id uuid.UUID, customer Customer) (Customer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exist := m.customers[id]; !exist {
+		return Customer{}, ErrCustomerNotFound
+	}
+
+	m.customers[id] = customer
+	return customer, nil
+}
+
+func (m *MemoryCustomerRepository) Delete(id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exist := m.customers[id]; !exist {
+		return ErrCustomerNotFound
+	}
+
+	delete(m.customers, id)
+	return nil
+}
+
+func (m *MemoryCustomerRepository) Get(id uuid.UUID) (Customer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	customer, exist := m.customers[id]
+	if !exist {
+		return Customer{}, ErrCustomerNotFound
+	}
+
+	return customer, nil
+}
+
+func (m *MemoryCustomerRepository) List() ([]Customer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]Customer, 0, len(m.customers))
+	for _, customer := range m.customers {
+		result = append(result, customer)
+	}
+
+	return result, nil
+}
+
+func (m *MemoryCustomerRepository) Search(query string) ([]Customer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	query = strings.ToLower(query)
+	result := make([]Customer, 0)
+	for _, customer := range m.customers {
+		if strings.Contains(strings.ToLower(customer.Name), query) ||
+			strings.Contains(strings.ToLower(customer.Email), query) ||
+			strings.Contains(strings.ToLower(customer.Role), query) ||
+			strings.Contains(strings.ToLower(customer.Phone), query) {
+			result = append(result, customer)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestMemoryCustomerRepository_Create(t *testing.T) {
+	repo := NewMemoryCustomerRepository()
+	id := uuid.New()
+	customer := Customer{Id: id, Name: "Alice", Role: "User", Email: "alice@example.com", Phone: "111", Contacted: true}
+
+	created, err := repo.Create(customer)
+	if err != nil {
+		t.Fatalf("Create returned unexpected error: %v", err)
+	}
+	if created != customer {
+		t.Errorf("Create returned %+v, want %+v", created, customer)
+	}
+
+	if _, err := repo.Create(customer); !errors.Is(err, ErrCustomerExists) {
+		t.Errorf("Create with a duplicate id returned %v, want ErrCustomerExists", err)
+	}
+}
+
+func TestMemoryCustomerRepository_Update(t *testing.T) {
+	repo := NewMemoryCustomerRepository()
+	id := uuid.New()
+	original := Customer{Id: id, Name: "Alice", Role: "User", Email: "alice@example.com", Phone: "111", Contacted: true}
+	if _, err := repo.Create(original); err != nil {
+		t.Fatalf("Create returned unexpected error: %v", err)
+	}
+
+	// The replacement clears every field, including the zero-value ones
+	// (empty Name, false Contacted), which Update must still apply.
+	replacement := Customer{Id: id, Name: "", Role: "", Email: "", Phone: "", Contacted: false}
+	updated, err := repo.Update(id, replacement)
+	if err != nil {
+		t.Fatalf("Update returned unexpected error: %v", err)
+	}
+	if updated != replacement {
+		t.Errorf("Update returned %+v, want %+v", updated, replacement)
+	}
+
+	stored, err := repo.Get(id)
+	if err != nil {
+		t.Fatalf("Get returned unexpected error: %v", err)
+	}
+	if stored != replacement {
+		t.Errorf("stored customer is %+v, want %+v", stored, replacement)
+	}
+
+	if _, err := repo.Update(uuid.New(), replacement); !errors.Is(err, ErrCustomerNotFound) {
+		t.Errorf("Update of an unknown id returned %v, want ErrCustomerNotFound", err)
+	}
+}
+
+func TestMemoryCustomerRepository_Delete(t *testing.T) {
+	repo := NewMemoryCustomerRepository()
+	id := uuid.New()
+	if _, err := repo.Create(Customer{Id: id, Name: "Alice"}); err != nil {
+		t.Fatalf("Create returned unexpected error: %v", err)
+	}
+
+	if err := repo.Delete(id); err != nil {
+		t.Fatalf("Delete returned unexpected error: %v", err)
+	}
+
+	if _, err := repo.Get(id); !errors.Is(err, ErrCustomerNotFound) {
+		t.Errorf("Get of a deleted customer returned %v, want ErrCustomerNotFound", err)
+	}
+
+	if err := repo.Delete(id); !errors.Is(err, ErrCustomerNotFound) {
+		t.Errorf("Delete of an unknown id returned %v, want ErrCustomerNotFound", err)
+	}
+}
+
+func TestMemoryCustomerRepository_Get(t *testing.T) {
+	repo := NewMemoryCustomerRepository()
+
+	if _, err := repo.Get(uuid.New()); !errors.Is(err, ErrCustomerNotFound) {
+		t.Errorf("Get of an unknown id returned %v, want ErrCustomerNotFound", err)
+	}
+
+	if _, err := repo.Get(johnDoeID); err != nil {
+		t.Errorf("Get of a seeded id returned unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// PostgresCustomerRepository is a Postgres-backed implementation of
+// CustomerRepository using GORM.
+type PostgresCustomerRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresCustomerRepository opens a connection to Postgres using the
+// PG_* settings on cfg and runs AutoMigrate for the Customer model.
+func NewPostgresCustomerRepository(cfg Config) (*PostgresCustomerRepository, error) {
+	db, err := gorm.Open(postgres.Open(postgresDSN(cfg)), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+
+	if err := db.AutoMigrate(&Customer{}); err != nil {
+		return nil, fmt.Errorf("failed to auto-migrate customer table: %w", err)
+	}
+
+	return &PostgresCustomerRepository{db: db}, nil
+}
+
+// postgresDSN builds a libpq-style DSN from cfg's PG_* settings.
+func postgresDSN(cfg Config) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		cfg.PGHost, cfg.PGPort, cfg.PGUser, cfg.PGPassword, cfg.PGDBName, cfg.PGSSLMode,
+	)
+}
+
+func (p *PostgresCustomerRepository) Create(customer Customer) (Customer, error) {
+	var existing Customer
+	if err := p.db.First(&existing, "id = ?", customer.Id).Error; err == nil {
+		return Customer{}, ErrCustomerExists
+	}
+
+	if err := p.db.Create(&customer).Error; err != nil {
+		return Customer{}, err
+	}
+
+	return customer, nil
+}
+
+func (p *PostgresCustomerRepository) Update(id uuid.UUID, customer Customer) (Customer, error) {
+	// Select("*") forces every column to be written, including zero values
+	// (empty strings, false); GORM's Updates otherwise skips zero-value
+	// fields, which would silently no-op a cleared field instead of
+	// replacing the row as CustomerRepository.Update promises.
+	result := p.db.Model(&Customer{}).Where("id = ?", id).Select("*").Updates(customer)
+	if result.Error != nil {
+		return Customer{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return Customer{}, ErrCustomerNotFound
+	}
+
+	customer.Id = id
+	return customer, nil
+}
+
+func (p *PostgresCustomerRepository) Delete(id uuid.UUID) error {
+	result := p.db.Delete(&Customer{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCustomerNotFound
+	}
+
+	return nil
+}
+
+func (p *PostgresCustomerRepository) Get(id uuid.UUID) (Customer, error) {
+	var customer Customer
+	if err := p.db.First(&customer, "id = ?", id).Error; err != nil {
+		return Customer{}, ErrCustomerNotFound
+	}
+
+	return customer, nil
+}
+
+func (p *PostgresCustomerRepository) List() ([]Customer, error) {
+	var customers []Customer
+	if err := p.db.Find(&customers).Error; err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+func (p *PostgresCustomerRepository) Search(query string) ([]Customer, error) {
+	var customers []Customer
+	like := "%" + strings.ToLower(query) + "%"
+	err := p.db.Where(
+		"LOWER(name) LIKE ? OR LOWER(email) LIKE ? OR LOWER(role) LIKE ? OR LOWER(phone) LIKE ?",
+		like, like, like, like,
+	).Find(&customers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPage    = 1
+	defaultPerPage = 20
+)
+
+// listQuery holds the parsed ?q=&page=&per_page=&sort=&order= parameters
+// accepted by GetCustomers.
+type listQuery struct {
+	Q       string
+	Page    int
+	PerPage int
+	Sort    string
+	Order   string
+}
+
+// parseListQuery reads the listing parameters off the request, applying
+// defaults for anything missing or invalid.
+func parseListQuery(r *http.Request) listQuery {
+	values := r.URL.Query()
+
+	q := listQuery{
+		Q:       values.Get("q"),
+		Page:    defaultPage,
+		PerPage: defaultPerPage,
+		Sort:    "id",
+		Order:   "asc",
+	}
+
+	if page, err := strconv.Atoi(values.Get("page")); err == nil && page > 0 {
+		q.Page = page
+	}
+
+	if perPage, err := strconv.Atoi(values.Get("per_page")); err == nil && perPage > 0 {
+		q.PerPage = perPage
+	}
+
+	switch values.Get("sort") {
+	case "name", "role", "email", "phone", "contacted":
+		q.Sort = values.Get("sort")
+	}
+
+	if values.Get("order") == "desc" {
+		q.Order = "desc"
+	}
+
+	return q
+}
+
+// sortCustomers orders customers in place by the given field and direction.
+// Unrecognized fields leave the slice ordered by id.
+func sortCustomers(customers []Customer, field, order string) {
+	less := func(i, j int) bool {
+		switch field {
+		case "name":
+			return customers[i].Name < customers[j].Name
+		case "role":
+			return customers[i].Role < customers[j].Role
+		case "email":
+			return customers[i].Email < customers[j].Email
+		case "phone":
+			return customers[i].Phone < customers[j].Phone
+		case "contacted":
+			return !customers[i].Contacted && customers[j].Contacted
+		default:
+			return customers[i].Id.String() < customers[j].Id.String()
+		}
+	}
+
+	if order == "desc" {
+		sort.Slice(customers, func(i, j int) bool { return less(j, i) })
+	} else {
+		sort.Slice(customers, less)
+	}
+}
+
+// paginate returns the slice of customers falling on the given 1-indexed
+// page, sized perPage.
+func paginate(customers []Customer, page, perPage int) []Customer {
+	start := (page - 1) * perPage
+	if start < 0 || start >= len(customers) {
+		return []Customer{}
+	}
+
+	end := start + perPage
+	if end > len(customers) {
+		end = len(customers)
+	}
+
+	return customers[start:end]
+}
+
+// buildPageURL returns the path + query string for page, preserving every
+// other query parameter on r.
+func buildPageURL(r *http.Request, page int) string {
+	values := r.URL.Query()
+	values.Set("page", strconv.Itoa(page))
+
+	u := url.URL{Path: r.URL.Path, RawQuery: values.Encode()}
+	return u.String()
+}
+
+// isHtmxSearchRequest reports whether r was issued by htmx in response to
+// the search input (identified by its "search" id), which should receive
+// just the rows fragment instead of the full paged envelope.
+func isHtmxSearchRequest(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("HX-Request"), "true") &&
+		r.Header.Get("HX-Trigger") == "search"
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrCustomerNotFound is returned by a CustomerRepository when the requested
+// customer id does not exist.
+var ErrCustomerNotFound = errors.New("customer not found")
+
+// ErrCustomerExists is returned by a CustomerRepository when attempting to
+// create a customer whose id already exists.
+var ErrCustomerExists = errors.New("customer already exists")
+
+// CustomerRepository abstracts the storage backend for customers so that
+// handlers can be written against an interface rather than a concrete
+// "database". This allows swapping the in-memory store used in development
+// for a persistent backend (e.g. Postgres) without touching handler code,
+// and makes the handlers testable with a mock implementation.
+type CustomerRepository interface {
+	// Create stores a new customer and returns the stored value. It returns
+	// ErrCustomerExists if a customer with the same id is already present.
+	Create(customer Customer) (Customer, error)
+
+	// Update replaces the customer stored under id with customer. It returns
+	// ErrCustomerNotFound if no customer with that id exists.
+	Update(id uuid.UUID, customer Customer) (Customer, error)
+
+	// Delete removes the customer stored under id. It returns
+	// ErrCustomerNotFound if no customer with that id exists.
+	Delete(id uuid.UUID) error
+
+	// Get returns the customer stored under id. It returns
+	// ErrCustomerNotFound if no customer with that id exists.
+	Get(id uuid.UUID) (Customer, error)
+
+	// List returns every customer in the repository.
+	List() ([]Customer, error)
+
+	// Search returns every customer whose Name, Email, Role, or Phone
+	// contains query (case-insensitive).
+	Search(query string) ([]Customer, error)
+}
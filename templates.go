@@ -0,0 +1,17 @@
+package main
+
+import (
+	"embed"
+	"html/template"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+//go:embed templates/*.gohtml
+var templateFiles embed.FS
+
+// templates is the parsed set of server-side views rendered for
+// `Accept: text/html` requests, shared by the JSON/HTML handlers in
+// main.go. sprig supplies the usual string/slice helpers on top of the
+// html/template builtins.
+var templates = template.Must(template.New("").Funcs(sprig.FuncMap()).ParseFS(templateFiles, "templates/*.gohtml"))